@@ -1,20 +1,26 @@
 package main
 
 import (
-	"bufio"
+	"flag"
 	"fmt"
+	"log/slog"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
-	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/joho/godotenv"
+
+	"github.com/Gsirawan/engram-memory/internal/gallery"
+	"github.com/Gsirawan/engram-memory/internal/logging"
+	"github.com/Gsirawan/engram-memory/internal/metrics"
+	"github.com/Gsirawan/engram-memory/internal/progress"
+	"github.com/Gsirawan/engram-memory/internal/supervisor"
 )
 
 var (
@@ -49,12 +55,20 @@ var (
 			Foreground(lipgloss.Color("#DDA0DD"))
 )
 
+const (
+	maxRestarts     = 5
+	shutdownGrace   = 5 * time.Second
+	metricsInterval = 15 * time.Second
+)
+
 type Step struct {
 	Name        string
 	Status      string
 	Description string
 	LogLines    []string
 	Info        string
+	Progress    *progress.Bar
+	StartedAt   time.Time
 }
 
 type Model struct {
@@ -68,8 +82,24 @@ type Model struct {
 	quitting    bool
 	ports       map[string]string
 	config      map[string]string
-	logMutex    sync.Mutex
-	processes   []*exec.Cmd
+
+	supervisor *supervisor.Supervisor
+	pidPath    string
+	notices    []string
+
+	logLevel       slog.Level
+	ollamaLogger   *logging.Logger
+	lightragLogger *logging.Logger
+
+	gallery         *gallery.Gallery
+	profileFlag     string
+	awaitingProfile bool
+	profileNames    []string
+	profileCursor   int
+	embeddingPreset gallery.Preset
+	llmPreset       gallery.Preset
+
+	metrics *metrics.Collector
 }
 
 type stepDoneMsg struct{ index int }
@@ -77,14 +107,99 @@ type stepErrorMsg struct {
 	index int
 	err   error
 }
-type logUpdateMsg struct {
-	index int
-	line  string
-}
 type configLoadedMsg struct {
 	config map[string]string
 }
 
+// streamEventMsg carries one progress.Event out of a running subprocess.
+// It also carries the channel and onFail callback forward so Update can
+// keep listening without the Model needing to track per-step state.
+type streamEventMsg struct {
+	index  int
+	ev     progress.Event
+	events <-chan progress.Event
+	onFail tea.Cmd
+}
+
+// listenStream waits for the next event on events and wraps it for Update.
+// Update re-issues this command after every non-terminal event, so the
+// stream keeps flowing until progress.Stream closes the channel.
+func listenStream(index int, events <-chan progress.Event, onFail tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-events
+		if !ok {
+			return stepDoneMsg{index: index}
+		}
+		return streamEventMsg{index: index, ev: ev, events: events, onFail: onFail}
+	}
+}
+
+// supervisorEventMsg carries a restart/give-up notice from the supervisor
+// up into the TUI, rendered as a footer notice rather than under a step
+// (by the time a child restarts, its step has usually already finished).
+type supervisorEventMsg struct{ ev supervisor.Event }
+
+func listenSupervisor(sup *supervisor.Supervisor) tea.Cmd {
+	return func() tea.Msg {
+		ev, ok := <-sup.Events()
+		if !ok {
+			return nil
+		}
+		return supervisorEventMsg{ev: ev}
+	}
+}
+
+// logRecordMsg carries one parsed log line from a service's Logger up into
+// the TUI, filtered to --log-level already (Logger.Events only forwards
+// what's at or above minLevel; everything still lands on disk either way).
+type logRecordMsg struct{ rec logging.Record }
+
+func listenLogs(logger *logging.Logger) tea.Cmd {
+	return func() tea.Msg {
+		rec, ok := <-logger.Events()
+		if !ok {
+			return nil
+		}
+		return logRecordMsg{rec: rec}
+	}
+}
+
+// metricsTickMsg fires periodically so engram_service_up and the child
+// CPU/RSS gauges on the /metrics endpoint stay current even once every step
+// has finished and nothing else is probing health.
+type metricsTickMsg struct{}
+
+func listenMetricsTick() tea.Cmd {
+	return tea.Tick(metricsInterval, func(time.Time) tea.Msg { return metricsTickMsg{} })
+}
+
+// metricsErrorMsg carries a /metrics bind failure up into the TUI as a
+// footer notice, the same way supervisorEventMsg does for restarts.
+type metricsErrorMsg struct{ err error }
+
+func listenMetricsErrors(mc *metrics.Collector) tea.Cmd {
+	return func() tea.Msg {
+		err, ok := <-mc.Events()
+		if !ok {
+			return nil
+		}
+		return metricsErrorMsg{err: err}
+	}
+}
+
+// sampleMetrics probes both services' health endpoints and every supervised
+// child's CPU/RSS, independent of whatever step is currently running.
+func (m Model) sampleMetrics() {
+	m.metrics.SetServiceUp("ollama", isHealthy(fmt.Sprintf("http://localhost:%s/api/tags", m.ports["ollama"])))
+	m.metrics.SetServiceUp("lightrag", isHealthy(fmt.Sprintf("http://localhost:%s/health", m.ports["lightrag"])))
+
+	for _, st := range m.supervisor.Statuses() {
+		if !st.Exited {
+			m.metrics.SampleProcess(st.Name, st.PID)
+		}
+	}
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -92,7 +207,32 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
-func initialModel(baseDir string) Model {
+// loadGallery finds the model gallery, preferring configs/gallery.yaml and
+// falling back to configs/models.yaml for repos that name it that way.
+func loadGallery(baseDir string) (*gallery.Gallery, error) {
+	for _, name := range []string{"gallery.yaml", "models.yaml"} {
+		path := filepath.Join(baseDir, "configs", name)
+		if _, err := os.Stat(path); err == nil {
+			return gallery.Load(path)
+		}
+	}
+	return nil, fmt.Errorf("no gallery file found (looked for configs/gallery.yaml, configs/models.yaml)")
+}
+
+// loadPorts reads configs/.env (if present) and returns the ports Engram's
+// services listen on. Shared by initialModel and `engram status` so both
+// agree on where to probe health.
+func loadPorts(baseDir string) map[string]string {
+	envPath := filepath.Join(baseDir, "configs", ".env")
+	godotenv.Load(envPath)
+
+	return map[string]string{
+		"ollama":   getEnv("OLLAMA_PORT", "11434"),
+		"lightrag": getEnv("LIGHTRAG_PORT", "9621"),
+	}
+}
+
+func initialModel(baseDir string, profileFlag string, logLevel slog.Level) Model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700"))
@@ -100,42 +240,91 @@ func initialModel(baseDir string) Model {
 	logsDir := filepath.Join(baseDir, "logs")
 	os.MkdirAll(logsDir, 0755)
 
-	envPath := filepath.Join(baseDir, "configs", ".env")
-	godotenv.Load(envPath)
+	ports := loadPorts(baseDir)
 
-	ports := map[string]string{
-		"ollama":   getEnv("OLLAMA_PORT", "11434"),
-		"lightrag": getEnv("LIGHTRAG_PORT", "9621"),
+	config := map[string]string{}
+
+	ollamaLogger, err := logging.New("ollama", filepath.Join(logsDir, "ollama.jsonl"), logLevel)
+	if err != nil {
+		return Model{err: fmt.Errorf("logging: %v", err)}
+	}
+	lightragLogger, err := logging.New("lightrag", filepath.Join(logsDir, "lightrag.jsonl"), logLevel)
+	if err != nil {
+		return Model{err: fmt.Errorf("logging: %v", err)}
 	}
 
-	config := map[string]string{}
+	mc := metrics.New()
+	mc.Serve(":" + metrics.Port())
 
 	steps := []Step{
 		{Name: "Python Deps", Description: "Sync Python dependencies (uv sync)", Status: "pending"},
 		{Name: "Ollama", Description: "Check/install Ollama", Status: "pending"},
 		{Name: "Ollama Server", Description: "Start Ollama server", Status: "pending"},
-		{Name: "Embedding Model", Description: "Pull qwen3-embedding:0.6b", Status: "pending"},
+		{Name: "Embedding Model", Description: "Pull embedding model", Status: "pending"},
 		{Name: "LightRAG", Description: "Start RAG pipeline", Status: "pending"},
 		{Name: "MCP Server", Description: "Verify lightrag-mcp available", Status: "pending"},
 	}
 
-	return Model{
-		steps:     steps,
-		spinner:   s,
-		baseDir:   baseDir,
-		logsDir:   logsDir,
-		ports:     ports,
-		config:    config,
-		processes: make([]*exec.Cmd, 0),
+	g, err := loadGallery(baseDir)
+	if err != nil {
+		return Model{err: fmt.Errorf("model gallery: %v", err)}
+	}
+
+	m := Model{
+		steps:       steps,
+		spinner:     s,
+		baseDir:     baseDir,
+		logsDir:     logsDir,
+		ports:       ports,
+		config:      config,
+		supervisor:  supervisor.New(maxRestarts),
+		pidPath:     filepath.Join(logsDir, "engram.pid"),
+		gallery:     g,
+		profileFlag: profileFlag,
+
+		logLevel:       logLevel,
+		ollamaLogger:   ollamaLogger,
+		lightragLogger: lightragLogger,
+
+		metrics: mc,
+	}
+
+	if profileFlag != "" {
+		embedding, llm, err := g.Resolve(profileFlag)
+		if err != nil {
+			m.err = fmt.Errorf("model gallery: %v", err)
+			return m
+		}
+		m.embeddingPreset = embedding
+		m.llmPreset = llm
+		return m
 	}
+
+	if _, err := g.DefaultProfile(); err != nil {
+		m.err = fmt.Errorf("model gallery: %v", err)
+		return m
+	}
+
+	for _, p := range g.Profiles {
+		m.profileNames = append(m.profileNames, p.Name)
+	}
+	m.awaitingProfile = true
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
-	return tea.Batch(m.spinner.Tick, m.runStep(0))
+	if m.err != nil {
+		return nil
+	}
+	if m.awaitingProfile {
+		return m.spinner.Tick
+	}
+	return tea.Batch(m.spinner.Tick, m.runStep(0), listenSupervisor(m.supervisor), listenLogs(m.ollamaLogger), listenLogs(m.lightragLogger), listenMetricsTick(), listenMetricsErrors(m.metrics))
 }
 
 func (m Model) runStep(index int) tea.Cmd {
 	return func() tea.Msg {
+		m.steps[index].StartedAt = time.Now()
 		switch index {
 		case 0:
 			return m.uvSync(index)
@@ -154,10 +343,23 @@ func (m Model) runStep(index int) tea.Cmd {
 	}
 }
 
+// uvSync streams the primary `uv sync --python 3.12` attempt with a progress
+// bar. If that attempt fails outright (not just slow), uvSyncFallback tries
+// the remaining Python versions the old blocking way — that path is rare
+// and not worth instrumenting with progress.
 func (m Model) uvSync(index int) tea.Msg {
-	// Try with --python flag first to handle systems with multiple Python versions
-	pythonVersions := []string{"3.12", "3.13", "3.11", ""}
+	cmd := exec.Command("uv", "sync", "--python", "3.12")
+	cmd.Dir = m.baseDir
+	events := progress.Stream(cmd, progress.ParseUvSyncLine)
+
+	onFail := func() tea.Msg {
+		return m.uvSyncFallback(index, []string{"3.13", "3.11", ""})
+	}
+
+	return listenStream(index, events, onFail)()
+}
 
+func (m Model) uvSyncFallback(index int, pythonVersions []string) tea.Msg {
 	var lastErr error
 	var lastOutput []byte
 
@@ -189,6 +391,16 @@ func (m Model) checkInstallOllama(index int) tea.Msg {
 	return stepErrorMsg{index: index, err: fmt.Errorf("Ollama not found. Install it from: https://ollama.ai/download")}
 }
 
+// newOllamaServeCmd builds a fresh `ollama serve` command, its output
+// scanned line-by-line into structured logs. The supervisor calls this
+// again on every restart.
+func (m Model) newOllamaServeCmd() (*exec.Cmd, error) {
+	cmd := exec.Command("ollama", "serve")
+	cmd.Stdout = m.ollamaLogger.Writer()
+	cmd.Stderr = m.ollamaLogger.Writer()
+	return cmd, nil
+}
+
 func (m Model) startOllama(index int) tea.Msg {
 	healthURL := fmt.Sprintf("http://localhost:%s/api/tags", m.ports["ollama"])
 
@@ -196,16 +408,7 @@ func (m Model) startOllama(index int) tea.Msg {
 		return stepDoneMsg{index: index}
 	}
 
-	logFile, err := os.Create(filepath.Join(m.logsDir, "ollama.log"))
-	if err != nil {
-		return stepErrorMsg{index: index, err: fmt.Errorf("failed to create log file: %v", err)}
-	}
-
-	cmd := exec.Command("ollama", "serve")
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
-	err = cmd.Start()
-	if err != nil {
+	if err := m.supervisor.Start("ollama", m.newOllamaServeCmd); err != nil {
 		return stepErrorMsg{index: index, err: fmt.Errorf("failed to start Ollama: %v", err)}
 	}
 
@@ -217,24 +420,76 @@ func (m Model) startOllama(index int) tea.Msg {
 }
 
 func (m Model) pullEmbeddingModel(index int) tea.Msg {
-	time.Sleep(2 * time.Second)
+	preset := m.embeddingPreset
 
-	for i := 0; i < 3; i++ {
-		cmd := exec.Command("ollama", "list")
-		output, err := cmd.Output()
-		if err == nil && strings.Contains(string(output), "qwen3-embedding") {
-			return stepDoneMsg{index: index}
+	switch preset.Backend {
+	case gallery.BackendOllama:
+		time.Sleep(2 * time.Second)
+
+		for i := 0; i < 3; i++ {
+			cmd := exec.Command("ollama", "list")
+			output, err := cmd.Output()
+			if err == nil && strings.Contains(string(output), preset.Model) {
+				return stepDoneMsg{index: index}
+			}
+			time.Sleep(1 * time.Second)
 		}
-		time.Sleep(1 * time.Second)
+
+		cmd := exec.Command("ollama", "pull", preset.Model)
+		events := progress.Stream(cmd, progress.ParseOllamaPullLine)
+		return listenStream(index, events, nil)()
+
+	case gallery.BackendOpenAI:
+		if os.Getenv("OPENAI_API_KEY") == "" {
+			return stepErrorMsg{index: index, err: fmt.Errorf("embedding preset %q uses OpenAI but OPENAI_API_KEY is not set", preset.Name)}
+		}
+		return stepDoneMsg{index: index}
+
+	case gallery.BackendLlamaCPP:
+		modelPath := filepath.Join(m.baseDir, "models", preset.Model)
+		if _, err := os.Stat(modelPath); err != nil {
+			return stepErrorMsg{index: index, err: fmt.Errorf("embedding preset %q expects a GGUF file at %s; download it and place it there", preset.Name, modelPath)}
+		}
+		return stepDoneMsg{index: index}
+
+	case gallery.BackendHuggingFace:
+		if _, err := exec.LookPath("huggingface-cli"); err != nil {
+			return stepErrorMsg{index: index, err: fmt.Errorf("embedding preset %q uses HuggingFace but huggingface-cli was not found; install it with `uv tool install huggingface_hub[cli]`", preset.Name)}
+		}
+		cmd := exec.Command("huggingface-cli", "download", preset.Model)
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return stepErrorMsg{index: index, err: fmt.Errorf("failed to download %s: %v - %s", preset.Model, err, string(output))}
+		}
+		return stepDoneMsg{index: index}
+
+	default:
+		return stepErrorMsg{index: index, err: fmt.Errorf("embedding preset %q uses unsupported backend %q (expected ollama, openai, llama.cpp, or huggingface)", preset.Name, preset.Backend)}
 	}
+}
 
-	cmd := exec.Command("ollama", "pull", "qwen3-embedding:0.6b")
-	output, err := cmd.CombinedOutput()
+// newLightRAGCmd builds a fresh lightrag-server command targeting the
+// resolved embedding/LLM presets, its output scanned line-by-line into
+// structured logs. The supervisor calls this again on every restart.
+func (m Model) newLightRAGCmd(ragStorageDir string) (*exec.Cmd, error) {
+	llmBinding, err := lightragBinding(m.llmPreset.Backend)
 	if err != nil {
-		return stepErrorMsg{index: index, err: fmt.Errorf("failed to pull: %v - %s", err, string(output))}
+		return nil, fmt.Errorf("llm preset %q: %w", m.llmPreset.Name, err)
+	}
+	embeddingBinding, err := lightragBinding(m.embeddingPreset.Backend)
+	if err != nil {
+		return nil, fmt.Errorf("embedding preset %q: %w", m.embeddingPreset.Name, err)
 	}
 
-	return stepDoneMsg{index: index}
+	cmd := exec.Command("uv", "run", "lightrag-server",
+		"--port", m.ports["lightrag"], "--host", "127.0.0.1",
+		"--llm-binding", llmBinding, "--llm-model", m.llmPreset.Model,
+		"--embedding-binding", embeddingBinding, "--embedding-model", m.embeddingPreset.Model,
+		"--working-dir", ragStorageDir)
+	cmd.Dir = m.baseDir
+	cmd.Stdout = m.lightragLogger.Writer()
+	cmd.Stderr = m.lightragLogger.Writer()
+	return cmd, nil
 }
 
 func (m *Model) startLightRAG(index int) tea.Msg {
@@ -251,24 +506,15 @@ func (m *Model) startLightRAG(index int) tea.Msg {
 		return stepErrorMsg{index: index, err: fmt.Errorf("failed to create rag_storage directory: %v", err)}
 	}
 
-	logPath := filepath.Join(m.logsDir, "lightrag.log")
-	logFile, err := os.Create(logPath)
-	if err != nil {
-		return stepErrorMsg{index: index, err: fmt.Errorf("failed to create log file: %v", err)}
-	}
-
-	cmd := exec.Command("uv", "run", "lightrag-server", "--port", m.ports["lightrag"], "--host", "127.0.0.1", "--llm-binding", "openai", "--embedding-binding", "ollama", "--working-dir", ragStorageDir)
-	cmd.Dir = m.baseDir
-	cmd.Stdout = logFile
-	cmd.Stderr = logFile
-
-	err = cmd.Start()
-	if err != nil {
+	if err := m.supervisor.Start("lightrag", func() (*exec.Cmd, error) {
+		return m.newLightRAGCmd(ragStorageDir)
+	}); err != nil {
 		return stepErrorMsg{index: index, err: fmt.Errorf("failed to start LightRAG: %v", err)}
 	}
 
 	if !waitForHealthy(healthURL, 60) {
-		logContent := readLastLines(logPath, 5)
+		logPath := filepath.Join(m.logsDir, "lightrag.jsonl")
+		logContent := tailLog(logPath, 5)
 		return stepErrorMsg{index: index, err: fmt.Errorf("LightRAG timeout. Last logs:\n%s", logContent)}
 	}
 
@@ -290,6 +536,21 @@ func (m *Model) verifyMCP(index int) tea.Msg {
 	return stepDoneMsg{index: index}
 }
 
+// lightragBinding maps a gallery backend onto the `--llm-binding` /
+// `--embedding-binding` values lightrag-server understands. llama.cpp and
+// huggingface aren't LightRAG bindings today, so those error with a hint
+// instead of silently falling back to Ollama.
+func lightragBinding(b gallery.Backend) (string, error) {
+	switch b {
+	case gallery.BackendOllama:
+		return "ollama", nil
+	case gallery.BackendOpenAI:
+		return "openai", nil
+	default:
+		return "", fmt.Errorf("backend %q is not a supported LightRAG binding (expected ollama or openai)", b)
+	}
+}
+
 func isHealthy(url string) bool {
 	client := http.Client{Timeout: 2 * time.Second}
 	resp, err := client.Get(url)
@@ -310,31 +571,109 @@ func waitForHealthy(url string, timeoutSeconds int) bool {
 	return false
 }
 
-func readLastLines(filePath string, n int) string {
-	file, err := os.Open(filePath)
+// tailLog reads the last n records from a logs/<service>.jsonl file, for
+// error context. It shows every level, not just what --log-level displays
+// live, since the on-disk file never drops anything.
+func tailLog(logPath string, n int) string {
+	records, err := logging.TailJSONL(logPath, n, slog.LevelDebug)
 	if err != nil {
 		return fmt.Sprintf("(could not read log: %v)", err)
 	}
-	defer file.Close()
 
-	var lines []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		lines = append(lines, scanner.Text())
-		if len(lines) > n {
-			lines = lines[1:]
-		}
+	lines := make([]string, len(records))
+	for i, rec := range records {
+		lines[i] = fmt.Sprintf("[%s] %s", rec.Level, rec.Msg)
 	}
 	return strings.Join(lines, "\n")
 }
 
+// stepForService maps a Logger's service name onto the step it should
+// render into, along with that same Logger so the caller can re-listen.
+func (m Model) stepForService(service string) (int, *logging.Logger) {
+	switch service {
+	case "ollama":
+		return 2, m.ollamaLogger
+	case "lightrag":
+		return 4, m.lightragLogger
+	default:
+		return -1, nil
+	}
+}
+
+// finishStep marks a step done and advances to the next one, or to the
+// "done" screen if it was the last step.
+func (m Model) finishStep(index int) (tea.Model, tea.Cmd) {
+	m.steps[index].Status = "done"
+	m.metrics.ObserveStep(m.steps[index].Name, time.Since(m.steps[index].StartedAt))
+	m.currentStep++
+	if m.currentStep >= len(m.steps) {
+		m.done = true
+		supervisor.WritePidfile(m.pidPath, m.supervisor.Statuses())
+		return m, nil
+	}
+	m.steps[m.currentStep].Status = "running"
+	return m, m.runStep(m.currentStep)
+}
+
+// failStep marks a step errored and surfaces err to the user.
+func (m Model) failStep(index int, err error) (tea.Model, tea.Cmd) {
+	m.steps[index].Status = "error"
+	m.metrics.ObserveStep(m.steps[index].Name, time.Since(m.steps[index].StartedAt))
+	m.err = err
+	return m, nil
+}
+
+// shutdown asks the supervisor to stop every child gracefully, then quits.
+// Run as a tea.Cmd so the grace period doesn't block the event loop.
+func (m Model) shutdown() tea.Msg {
+	m.supervisor.Shutdown(shutdownGrace)
+	return tea.Quit()
+}
+
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if m.awaitingProfile {
+			switch msg.String() {
+			case "ctrl+c", "q":
+				m.quitting = true
+				return m, tea.Quit
+			case "up", "k":
+				if m.profileCursor > 0 {
+					m.profileCursor--
+				}
+				return m, nil
+			case "down", "j":
+				if m.profileCursor < len(m.profileNames)-1 {
+					m.profileCursor++
+				}
+				return m, nil
+			case "enter":
+				name := m.profileNames[m.profileCursor]
+				embedding, llm, err := m.gallery.Resolve(name)
+				if err != nil {
+					m.err = fmt.Errorf("model gallery: %v", err)
+					return m, nil
+				}
+				m.embeddingPreset = embedding
+				m.llmPreset = llm
+				m.profileFlag = name
+				m.awaitingProfile = false
+				m.steps[0].Status = "running"
+				return m, tea.Batch(m.runStep(0), listenSupervisor(m.supervisor), listenLogs(m.ollamaLogger), listenLogs(m.lightragLogger), listenMetricsTick(), listenMetricsErrors(m.metrics))
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "ctrl+c", "q":
 			m.quitting = true
-			return m, tea.Quit
+			if m.supervisor == nil {
+				// initialModel returned before creating the supervisor
+				// (logging.New or loadGallery failed), so no child is running.
+				return m, tea.Quit
+			}
+			return m, m.shutdown
 		}
 
 	case spinner.TickMsg:
@@ -343,29 +682,66 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, cmd
 
 	case stepDoneMsg:
-		m.steps[msg.index].Status = "done"
-		m.currentStep++
-		if m.currentStep >= len(m.steps) {
-			m.done = true
-			return m, nil
-		}
-		m.steps[m.currentStep].Status = "running"
-		return m, m.runStep(m.currentStep)
+		return m.finishStep(msg.index)
 
 	case stepErrorMsg:
-		m.steps[msg.index].Status = "error"
-		m.err = msg.err
-		return m, nil
+		return m.failStep(msg.index, msg.err)
+
+	case streamEventMsg:
+		ev := msg.ev
+		if ev.Done {
+			if ev.Err != nil {
+				if msg.onFail != nil {
+					return m, msg.onFail
+				}
+				return m.failStep(msg.index, ev.Err)
+			}
+			return m.finishStep(msg.index)
+		}
 
-	case logUpdateMsg:
-		m.logMutex.Lock()
 		step := &m.steps[msg.index]
-		step.LogLines = append(step.LogLines, msg.line)
-		if len(step.LogLines) > 3 {
-			step.LogLines = step.LogLines[len(step.LogLines)-3:]
+		if ev.Update.Total > 0 || ev.Update.Percent > 0 {
+			bar := progress.Bar{Label: step.Name, Width: 40, Update: ev.Update}
+			step.Progress = &bar
+		} else if ev.Line != "" {
+			step.LogLines = append(step.LogLines, ev.Line)
+			if len(step.LogLines) > 3 {
+				step.LogLines = step.LogLines[len(step.LogLines)-3:]
+			}
 		}
-		m.logMutex.Unlock()
-		return m, nil
+		return m, listenStream(msg.index, msg.events, msg.onFail)
+
+	case supervisorEventMsg:
+		m.notices = append(m.notices, fmt.Sprintf("%s: %s", msg.ev.Name, msg.ev.Msg))
+		if len(m.notices) > 5 {
+			m.notices = m.notices[len(m.notices)-5:]
+		}
+		if strings.Contains(msg.ev.Msg, "restarting in") {
+			m.metrics.IncRestart(msg.ev.Name)
+		}
+		return m, listenSupervisor(m.supervisor)
+
+	case metricsTickMsg:
+		m.sampleMetrics()
+		return m, listenMetricsTick()
+
+	case metricsErrorMsg:
+		m.notices = append(m.notices, fmt.Sprintf("metrics: %v", msg.err))
+		if len(m.notices) > 5 {
+			m.notices = m.notices[len(m.notices)-5:]
+		}
+		return m, listenMetricsErrors(m.metrics)
+
+	case logRecordMsg:
+		index, logger := m.stepForService(msg.rec.Service)
+		if index >= 0 {
+			step := &m.steps[index]
+			step.LogLines = append(step.LogLines, fmt.Sprintf("[%s] %s", msg.rec.Level, msg.rec.Msg))
+			if len(step.LogLines) > 3 {
+				step.LogLines = step.LogLines[len(step.LogLines)-3:]
+			}
+		}
+		return m, listenLogs(logger)
 	}
 
 	return m, nil
@@ -379,6 +755,24 @@ func (m Model) View() string {
 	b.WriteString(title)
 	b.WriteString("\n\n")
 
+	if m.awaitingProfile {
+		b.WriteString(configStyle.Render("  Pick a model profile:"))
+		b.WriteString("\n\n")
+		for i, name := range m.profileNames {
+			cursor := "  "
+			style := dimStyle
+			if i == m.profileCursor {
+				cursor = "â–¸ "
+				style = accentStyle
+			}
+			b.WriteString(style.Render(fmt.Sprintf("  %s%s\n", cursor, name)))
+		}
+		b.WriteString("\n")
+		b.WriteString(dimStyle.Render("  â†‘/â†“ to choose, enter to select, q to quit"))
+		b.WriteString("\n")
+		return b.String()
+	}
+
 	for i, step := range m.steps {
 		var icon string
 		var status string
@@ -402,7 +796,9 @@ func (m Model) View() string {
 		b.WriteString(line)
 		b.WriteString("\n")
 
-		if len(step.LogLines) > 0 && step.Status == "running" {
+		if step.Progress != nil && step.Status == "running" {
+			b.WriteString(logStyle.Render(fmt.Sprintf("    â”‚ %s\n", step.Progress.View())))
+		} else if len(step.LogLines) > 0 && step.Status == "running" {
 			for _, logLine := range step.LogLines {
 				truncated := logLine
 				if len(truncated) > 70 {
@@ -412,7 +808,7 @@ func (m Model) View() string {
 			}
 		}
 
-		if step.Status == "running" && len(step.LogLines) == 0 {
+		if step.Status == "running" && step.Progress == nil && len(step.LogLines) == 0 {
 			hint := ""
 			switch i {
 			case 0:
@@ -453,12 +849,68 @@ func (m Model) View() string {
 		b.WriteString(dimStyle.Render("  Setting up... Press 'q' to cancel"))
 	}
 
+	if len(m.notices) > 0 {
+		b.WriteString("\n\n")
+		for _, notice := range m.notices {
+			b.WriteString(waitingStyle.Render(fmt.Sprintf("  âš  %s", notice)))
+			b.WriteString("\n")
+		}
+	}
+
 	b.WriteString("\n")
 
 	return b.String()
 }
 
+// statusCmd implements `engram status`: read the pidfile the TUI wrote on
+// startup and report each service's health, uptime, and restart history
+// without needing the TUI itself running.
+func statusCmd(baseDir string) {
+	ports := loadPorts(baseDir)
+	pidPath := filepath.Join(baseDir, "logs", "engram.pid")
+
+	statuses, err := supervisor.ReadPidfile(pidPath)
+	if err != nil {
+		fmt.Printf("engram status: %v\n", err)
+		fmt.Println("Is Engram running? The pidfile is written once startup completes.")
+		os.Exit(1)
+	}
+
+	healthURLs := map[string]string{
+		"ollama":   fmt.Sprintf("http://localhost:%s/api/tags", ports["ollama"]),
+		"lightrag": fmt.Sprintf("http://localhost:%s/health", ports["lightrag"]),
+	}
+
+	for _, st := range statuses {
+		health := "down"
+		if url, ok := healthURLs[st.Name]; ok && isHealthy(url) {
+			health = "up"
+		}
+
+		uptime := time.Since(st.StartTime).Truncate(time.Second)
+		fmt.Printf("%-10s %-5s pid=%-8d uptime=%-10s restarts=%d", st.Name, health, st.PID, uptime, st.Restarts)
+		if st.LastRestartReason != "" {
+			fmt.Printf(" last_restart=%q", st.LastRestartReason)
+		}
+		fmt.Println()
+	}
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "status" {
+		baseDir, err := os.Getwd()
+		if err != nil {
+			fmt.Println("Error getting current directory:", err)
+			os.Exit(1)
+		}
+		statusCmd(baseDir)
+		return
+	}
+
+	profileFlag := flag.String("profile", "", "model gallery profile to use (see configs/gallery.yaml); prompts interactively if omitted")
+	logLevelFlag := flag.String("log-level", "info", "minimum level to show live in the TUI (debug, info, warn, error); the on-disk logs/<service>.jsonl always has everything")
+	flag.Parse()
+
 	baseDir, err := os.Getwd()
 	if err != nil {
 		fmt.Println("Error getting current directory:", err)
@@ -471,7 +923,7 @@ func main() {
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(initialModel(baseDir))
+	p := tea.NewProgram(initialModel(baseDir, *profileFlag, logging.ParseLevel(*logLevelFlag)))
 	if _, err := p.Run(); err != nil {
 		fmt.Printf("Error: %v", err)
 		os.Exit(1)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestShippedProfilesAreRunnable guards against a profile shipping a
+// preset combination gallery.Resolve accepts but lightragBinding doesn't
+// (e.g. local-only pointing at a llama.cpp/huggingface preset neither
+// LightRAG binding supports today).
+func TestShippedProfilesAreRunnable(t *testing.T) {
+	g, err := loadGallery("../..")
+	if err != nil {
+		t.Fatalf("loadGallery: %v", err)
+	}
+
+	for _, p := range g.Profiles {
+		embedding, llm, err := g.Resolve(p.Name)
+		if err != nil {
+			t.Fatalf("Resolve(%q): %v", p.Name, err)
+		}
+		if _, err := lightragBinding(embedding.Backend); err != nil {
+			t.Errorf("profile %q: embedding preset %q: %v", p.Name, embedding.Name, err)
+		}
+		if _, err := lightragBinding(llm.Backend); err != nil {
+			t.Errorf("profile %q: llm preset %q: %v", p.Name, llm.Name, err)
+		}
+	}
+}
+
+// TestInitialModelRejectsEmptyGallery guards against entering the
+// interactive profile picker with zero profiles, which used to panic on
+// the first "enter" keypress (m.profileNames[m.profileCursor] out of range).
+func TestInitialModelRejectsEmptyGallery(t *testing.T) {
+	baseDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(baseDir, "configs"), 0o755); err != nil {
+		t.Fatalf("MkdirAll: %v", err)
+	}
+	err := os.WriteFile(filepath.Join(baseDir, "configs", "gallery.yaml"), []byte("presets: []\nprofiles: []\n"), 0o644)
+	if err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	m := initialModel(baseDir, "", slog.LevelInfo)
+	if m.err == nil {
+		t.Fatal("expected an error for a gallery with zero profiles, got nil")
+	}
+	if m.awaitingProfile {
+		t.Error("awaitingProfile = true, want false so the picker never opens with no profiles")
+	}
+}
@@ -0,0 +1,138 @@
+// Package gallery loads the model gallery (configs/gallery.yaml): named
+// presets and profiles for the embedding/LLM backends Engram can target.
+package gallery
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Backend identifies which binding a Preset targets.
+type Backend string
+
+const (
+	BackendOllama      Backend = "ollama"
+	BackendOpenAI      Backend = "openai"
+	BackendLlamaCPP    Backend = "llama.cpp"
+	BackendHuggingFace Backend = "huggingface"
+)
+
+var knownBackends = map[Backend]bool{
+	BackendOllama:      true,
+	BackendOpenAI:      true,
+	BackendLlamaCPP:    true,
+	BackendHuggingFace: true,
+}
+
+// Role says whether a Preset fills the embedding or the LLM slot of a profile.
+type Role string
+
+const (
+	RoleEmbedding Role = "embedding"
+	RoleLLM       Role = "llm"
+)
+
+// Preset is one named model configuration: a backend, a model id, and the
+// knobs that backend needs (quantization, embedding dimension, prompt
+// template). Which fields are meaningful depends on Role and Backend.
+type Preset struct {
+	Name           string  `yaml:"name"`
+	Role           Role    `yaml:"role"`
+	Backend        Backend `yaml:"backend"`
+	Model          string  `yaml:"model"`
+	Quantization   string  `yaml:"quantization,omitempty"`
+	EmbeddingDim   int     `yaml:"embedding_dim,omitempty"`
+	PromptTemplate string  `yaml:"prompt_template,omitempty"`
+}
+
+// Profile pairs an embedding preset with an LLM preset by name.
+type Profile struct {
+	Name      string `yaml:"name"`
+	Embedding string `yaml:"embedding"`
+	LLM       string `yaml:"llm"`
+}
+
+// Gallery is the parsed contents of gallery.yaml.
+type Gallery struct {
+	Presets  []Preset  `yaml:"presets"`
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// Load reads and validates the gallery file at path.
+func Load(path string) (*Gallery, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("gallery: reading %s: %w", path, err)
+	}
+
+	var g Gallery
+	if err := yaml.Unmarshal(data, &g); err != nil {
+		return nil, fmt.Errorf("gallery: parsing %s: %w", path, err)
+	}
+
+	for _, p := range g.Presets {
+		if !knownBackends[p.Backend] {
+			return nil, fmt.Errorf("gallery: preset %q uses unknown backend %q (expected one of ollama, openai, llama.cpp, huggingface)", p.Name, p.Backend)
+		}
+	}
+
+	return &g, nil
+}
+
+// Preset looks up a preset by name.
+func (g *Gallery) Preset(name string) (*Preset, bool) {
+	for i := range g.Presets {
+		if g.Presets[i].Name == name {
+			return &g.Presets[i], true
+		}
+	}
+	return nil, false
+}
+
+// PresetsByRole returns every preset with the given role, in file order.
+func (g *Gallery) PresetsByRole(role Role) []Preset {
+	var out []Preset
+	for _, p := range g.Presets {
+		if p.Role == role {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// Resolve looks up a profile by name and returns its embedding and LLM
+// presets. It errors if the profile or either referenced preset is missing.
+func (g *Gallery) Resolve(profileName string) (embedding Preset, llm Preset, err error) {
+	var profile *Profile
+	for i := range g.Profiles {
+		if g.Profiles[i].Name == profileName {
+			profile = &g.Profiles[i]
+			break
+		}
+	}
+	if profile == nil {
+		return Preset{}, Preset{}, fmt.Errorf("gallery: no profile named %q", profileName)
+	}
+
+	embeddingPreset, ok := g.Preset(profile.Embedding)
+	if !ok {
+		return Preset{}, Preset{}, fmt.Errorf("gallery: profile %q references unknown embedding preset %q", profileName, profile.Embedding)
+	}
+	llmPreset, ok := g.Preset(profile.LLM)
+	if !ok {
+		return Preset{}, Preset{}, fmt.Errorf("gallery: profile %q references unknown llm preset %q", profileName, profile.LLM)
+	}
+
+	return *embeddingPreset, *llmPreset, nil
+}
+
+// DefaultProfile returns the name of the gallery's first profile, or an
+// error if the gallery defines no profiles.
+func (g *Gallery) DefaultProfile() (string, error) {
+	if len(g.Profiles) == 0 {
+		return "", fmt.Errorf("gallery: no profiles defined")
+	}
+	return g.Profiles[0].Name, nil
+}
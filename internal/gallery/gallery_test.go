@@ -0,0 +1,42 @@
+package gallery
+
+import (
+	"os"
+	"testing"
+)
+
+// TestLoadShippedGallery guards against the shipped configs/gallery.yaml
+// drifting out of sync with the Backend constants (e.g. a preset using a
+// backend spelling Load doesn't recognize).
+func TestLoadShippedGallery(t *testing.T) {
+	g, err := Load("../../configs/gallery.yaml")
+	if err != nil {
+		t.Fatalf("Load(configs/gallery.yaml): %v", err)
+	}
+
+	for _, name := range []string{"default", "local-only", "openai-only"} {
+		if _, _, err := g.Resolve(name); err != nil {
+			t.Errorf("Resolve(%q): %v", name, err)
+		}
+	}
+}
+
+func TestLoadUnknownBackend(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/gallery.yaml"
+	contents := `
+presets:
+  - name: bad
+    role: llm
+    backend: not-a-backend
+    model: whatever
+profiles: []
+`
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	if _, err := Load(path); err == nil {
+		t.Error("expected error for unknown backend")
+	}
+}
@@ -0,0 +1,202 @@
+// Package logging wraps log/slog with a JSON handler that writes every
+// child process's output to a rotated logs/<service>.jsonl file, and a
+// level filter that decides what also gets forwarded to the TUI.
+package logging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Record is one structured log line, shared by the TUI-facing channel and
+// the on-disk JSON.
+type Record struct {
+	Service string
+	Level   string
+	Time    time.Time
+	Msg     string
+}
+
+// ParseLevel maps a --log-level flag value onto a slog.Level, defaulting to
+// Info for anything unrecognized.
+func ParseLevel(s string) slog.Level {
+	switch strings.ToUpper(strings.TrimSpace(s)) {
+	case "DEBUG":
+		return slog.LevelDebug
+	case "WARN", "WARNING":
+		return slog.LevelWarn
+	case "ERROR":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+func levelFromString(s string) slog.Level {
+	return ParseLevel(s)
+}
+
+// Logger scans one service's stdout/stderr, writes every line to a rotated
+// JSON file regardless of level, and forwards lines at minLevel or above on
+// Events() for the TUI to render.
+type Logger struct {
+	service  string
+	json     *slog.Logger
+	minLevel slog.Level
+	events   chan Record
+}
+
+// New opens (creating if needed) logPath with 10MB x 5 file rotation and
+// returns a Logger for service that writes JSON there.
+func New(service, logPath string, minLevel slog.Level) (*Logger, error) {
+	rot, err := NewRotator(logPath, 10*1024*1024, 5)
+	if err != nil {
+		return nil, fmt.Errorf("logging: opening %s: %w", logPath, err)
+	}
+
+	handler := slog.NewJSONHandler(rot, &slog.HandlerOptions{Level: slog.LevelDebug})
+	return &Logger{
+		service:  service,
+		json:     slog.New(handler).With("service", service),
+		minLevel: minLevel,
+		events:   make(chan Record, 64),
+	}, nil
+}
+
+// Events returns the channel of Records at or above minLevel. It's buffered
+// and non-blocking on send, so a TUI that falls behind drops screen updates
+// rather than stalling the child process's output.
+func (l *Logger) Events() <-chan Record {
+	return l.events
+}
+
+// Writer returns a fresh io.Writer that splits whatever is written to it
+// into lines and ingests each one. Call it once per stream (stdout, stderr)
+// you attach to this logger — each call gets its own line buffer, so
+// concurrent writes from both streams never interleave mid-line.
+func (l *Logger) Writer() *lineWriter {
+	return &lineWriter{onLine: l.ingestLine}
+}
+
+func (l *Logger) ingestLine(line string) {
+	rec := ParseLine(l.service, line)
+
+	level := levelFromString(rec.Level)
+	l.json.LogAttrs(context.Background(), level, rec.Msg)
+
+	if level >= l.minLevel {
+		select {
+		case l.events <- rec:
+		default:
+			// TUI isn't draining fast enough; the file already has it.
+		}
+	}
+}
+
+// lineWriter buffers partial writes and calls onLine once per complete
+// line, the way a bufio.Scanner would if cmd.Stdout/Stderr were a pipe we
+// had to scan ourselves. Using it as an ordinary io.Writer instead means
+// the stdlib's os/exec already waits for it to drain before cmd.Wait
+// returns, so there's no pipe-vs-Wait race to manage.
+type lineWriter struct {
+	buf    []byte
+	onLine func(string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		line := string(bytes.TrimRight(w.buf[:i], "\r"))
+		w.onLine(line)
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// lightragLineRE matches Python logging's default format, e.g.:
+//
+//	2024-01-15 10:23:45,123 - lightrag - INFO - Starting server
+var lightragLineRE = regexp.MustCompile(`^\S+ \S+ - (\S+) - (DEBUG|INFO|WARNING|WARN|ERROR|CRITICAL) - (.*)$`)
+
+// slogLineRE matches log/slog's default text handler, e.g.:
+//
+//	time=2024-01-15T10:23:45.123Z level=INFO msg="starting server"
+var slogLineRE = regexp.MustCompile(`\blevel=(\w+)\s+msg=("(?:[^"\\]|\\.)*"|\S+)`)
+
+// ParseLine best-effort parses one line of child output into a Record.
+// LightRAG emits Python logging lines, Ollama emits slog-ish ones; anything
+// else is kept verbatim at Info level rather than dropped.
+func ParseLine(service, line string) Record {
+	now := time.Now()
+
+	if m := lightragLineRE.FindStringSubmatch(line); m != nil {
+		return Record{Service: service, Level: normalizeLevel(m[2]), Time: now, Msg: m[3]}
+	}
+	if m := slogLineRE.FindStringSubmatch(line); m != nil {
+		msg := strings.Trim(m[2], `"`)
+		return Record{Service: service, Level: normalizeLevel(m[1]), Time: now, Msg: msg}
+	}
+
+	return Record{Service: service, Level: "INFO", Time: now, Msg: line}
+}
+
+func normalizeLevel(level string) string {
+	switch strings.ToUpper(level) {
+	case "WARNING":
+		return "WARN"
+	case "CRITICAL":
+		return "ERROR"
+	default:
+		return strings.ToUpper(level)
+	}
+}
+
+// jsonLine is what a Record round-trips through on disk, matching
+// slog.JSONHandler's field names.
+type jsonLine struct {
+	Time    time.Time `json:"time"`
+	Level   string    `json:"level"`
+	Msg     string    `json:"msg"`
+	Service string    `json:"service"`
+}
+
+// TailJSONL reads a logs/<service>.jsonl file written by a Logger and
+// returns the last n records at or above minLevel — a structured
+// replacement for tailing raw log lines.
+func TailJSONL(path string, n int, minLevel slog.Level) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("logging: reading %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var matched []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var jl jsonLine
+		if err := json.Unmarshal(scanner.Bytes(), &jl); err != nil {
+			continue
+		}
+		if levelFromString(jl.Level) < minLevel {
+			continue
+		}
+		matched = append(matched, Record{Service: jl.Service, Level: jl.Level, Time: jl.Time, Msg: jl.Msg})
+		if len(matched) > n {
+			matched = matched[1:]
+		}
+	}
+	return matched, nil
+}
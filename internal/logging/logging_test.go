@@ -0,0 +1,48 @@
+package logging
+
+import "testing"
+
+func TestParseLineLightRAG(t *testing.T) {
+	rec := ParseLine("lightrag", "2024-01-15 10:23:45,123 - lightrag - INFO - Starting server")
+	if rec.Level != "INFO" {
+		t.Errorf("Level = %q, want INFO", rec.Level)
+	}
+	if rec.Msg != "Starting server" {
+		t.Errorf("Msg = %q, want %q", rec.Msg, "Starting server")
+	}
+}
+
+func TestParseLineSlog(t *testing.T) {
+	rec := ParseLine("ollama", `time=2024-01-15T10:23:45.123Z level=WARN msg="listener closing"`)
+	if rec.Level != "WARN" {
+		t.Errorf("Level = %q, want WARN", rec.Level)
+	}
+	if rec.Msg != "listener closing" {
+		t.Errorf("Msg = %q, want %q", rec.Msg, "listener closing")
+	}
+}
+
+func TestParseLineFallback(t *testing.T) {
+	rec := ParseLine("ollama", "some unstructured line")
+	if rec.Level != "INFO" {
+		t.Errorf("Level = %q, want INFO", rec.Level)
+	}
+	if rec.Msg != "some unstructured line" {
+		t.Errorf("Msg = %q, want input verbatim", rec.Msg)
+	}
+}
+
+func TestLineWriterSplitsOnNewline(t *testing.T) {
+	var lines []string
+	w := &lineWriter{onLine: func(l string) { lines = append(lines, l) }}
+
+	w.Write([]byte("hello wor"))
+	w.Write([]byte("ld\nsecond line\npartial"))
+
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2: %v", len(lines), lines)
+	}
+	if lines[0] != "hello world" || lines[1] != "second line" {
+		t.Errorf("lines = %v", lines)
+	}
+}
@@ -0,0 +1,92 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// Rotator is an io.Writer over a file that rotates to path.1, path.2, ...
+// once it exceeds maxBytes, keeping at most maxFiles total (the live file
+// plus maxFiles-1 rotated ones).
+type Rotator struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	maxFiles int
+	file     *os.File
+	size     int64
+}
+
+// NewRotator opens (creating if needed) path for appending.
+func NewRotator(path string, maxBytes int64, maxFiles int) (*Rotator, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &Rotator{
+		path:     path,
+		maxBytes: maxBytes,
+		maxFiles: maxFiles,
+		file:     f,
+		size:     info.Size(),
+	}, nil
+}
+
+func (r *Rotator) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.size > 0 && r.size+int64(len(p)) > r.maxBytes {
+		if err := r.rotate(); err != nil {
+			return 0, fmt.Errorf("logging: rotating %s: %w", r.path, err)
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate drops path.(maxFiles-1) (the oldest), shifts path.(maxFiles-2) ->
+// path.(maxFiles-1), ..., path -> path.1, then reopens path fresh, keeping
+// at most maxFiles total on disk (the live file plus maxFiles-1 rotated).
+func (r *Rotator) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+
+	for i := r.maxFiles - 1; i >= 1; i-- {
+		oldPath := fmt.Sprintf("%s.%d", r.path, i)
+		if _, err := os.Stat(oldPath); err != nil {
+			continue
+		}
+		if i == r.maxFiles-1 {
+			os.Remove(oldPath)
+			continue
+		}
+		os.Rename(oldPath, fmt.Sprintf("%s.%d", r.path, i+1))
+	}
+	os.Rename(r.path, r.path+".1")
+
+	f, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	r.file = f
+	r.size = 0
+	return nil
+}
+
+// Close closes the underlying file.
+func (r *Rotator) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.file.Close()
+}
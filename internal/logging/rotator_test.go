@@ -0,0 +1,34 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRotatorCapsTotalFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "service.jsonl")
+
+	r, err := NewRotator(path, 100, 5)
+	if err != nil {
+		t.Fatalf("NewRotator: %v", err)
+	}
+	defer r.Close()
+
+	for i := 0; i < 200; i++ {
+		if _, err := r.Write([]byte("0123456789\n")); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + "*")
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	if len(matches) > 5 {
+		t.Errorf("got %d files on disk, want at most 5: %v", len(matches), matches)
+	}
+	if _, err := os.Stat(path + ".5"); err == nil {
+		t.Errorf("%s.5 should not exist; only maxFiles-1 rotated files are kept", path)
+	}
+}
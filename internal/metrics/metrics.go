@@ -0,0 +1,219 @@
+// Package metrics exposes a Prometheus /metrics endpoint for the engram
+// orchestrator itself: how long each setup step took, whether Ollama and
+// LightRAG are currently healthy, how often the supervisor has restarted
+// them, and how much CPU/memory their child processes are using.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultPort is used when ENGRAM_METRICS_PORT is unset.
+const DefaultPort = "9620"
+
+// Collector owns one engram process's Prometheus registry. Callers record
+// into it from the places that already know what happened (a step
+// finishing, a supervisor restart) and call SampleProcess/SetServiceUp on a
+// ticker for the things that drift between those events.
+type Collector struct {
+	registry        *prometheus.Registry
+	stepDuration    *prometheus.HistogramVec
+	serviceUp       *prometheus.GaugeVec
+	serviceRestarts *prometheus.CounterVec
+	childCPU        *prometheus.GaugeVec
+	childRSS        *prometheus.GaugeVec
+	errs            chan error
+}
+
+// New builds a Collector with its own registry, so engram's metrics never
+// mix with whatever else might register on prometheus's default registry.
+func New() *Collector {
+	c := &Collector{
+		registry: prometheus.NewRegistry(),
+		stepDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "engram_step_duration_seconds",
+			Help:    "How long each orchestrator setup step took to complete.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"step"}),
+		serviceUp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "engram_service_up",
+			Help: "1 if the service's health endpoint last responded OK, 0 otherwise.",
+		}, []string{"service"}),
+		serviceRestarts: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "engram_service_restarts_total",
+			Help: "Total supervisor-triggered restarts of a service.",
+		}, []string{"service"}),
+		childCPU: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "engram_child_process_cpu_seconds",
+			Help: "Cumulative CPU time consumed by a supervised child process.",
+		}, []string{"service"}),
+		childRSS: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "engram_child_process_rss_bytes",
+			Help: "Resident set size of a supervised child process.",
+		}, []string{"service"}),
+		errs: make(chan error, 1),
+	}
+	c.registry.MustRegister(c.stepDuration, c.serviceUp, c.serviceRestarts, c.childCPU, c.childRSS)
+	return c
+}
+
+// ObserveStep records how long a setup step took, success or failure.
+func (c *Collector) ObserveStep(step string, d time.Duration) {
+	c.stepDuration.WithLabelValues(step).Observe(d.Seconds())
+}
+
+// SetServiceUp records the result of a health probe the caller already ran.
+func (c *Collector) SetServiceUp(service string, up bool) {
+	v := 0.0
+	if up {
+		v = 1
+	}
+	c.serviceUp.WithLabelValues(service).Set(v)
+}
+
+// IncRestart records one supervisor-triggered restart of service.
+func (c *Collector) IncRestart(service string) {
+	c.serviceRestarts.WithLabelValues(service).Inc()
+}
+
+// SampleProcess updates the CPU/RSS gauges for a supervised child's pid. A
+// sampling failure (process already gone, /proc unavailable) is silently
+// skipped rather than surfaced, since it just means the next tick retries.
+func (c *Collector) SampleProcess(service string, pid int) {
+	cpuSeconds, rssBytes, err := readProcStat(pid)
+	if err != nil {
+		cpuSeconds, rssBytes, err = readProcViaPS(pid)
+		if err != nil {
+			return
+		}
+	}
+	c.childCPU.WithLabelValues(service).Set(cpuSeconds)
+	c.childRSS.WithLabelValues(service).Set(float64(rssBytes))
+}
+
+// Serve starts the /metrics HTTP server in the background. A bind failure
+// is sent on Events() rather than killing the orchestrator or writing to
+// stderr directly — metrics are secondary to actually starting the
+// services, and a raw stderr write would corrupt the TUI's rendering.
+func (c *Collector) Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{}))
+	go func() {
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			c.errs <- fmt.Errorf("metrics: %w", err)
+		}
+	}()
+}
+
+// Events returns the channel Serve's bind-failure notice is sent on, if
+// any. Callers should drain it (e.g. forward into a Bubble Tea program).
+func (c *Collector) Events() <-chan error {
+	return c.errs
+}
+
+// Port resolves the metrics listen port: ENGRAM_METRICS_PORT if set, else
+// DefaultPort.
+func Port() string {
+	if p := os.Getenv("ENGRAM_METRICS_PORT"); p != "" {
+		return p
+	}
+	return DefaultPort
+}
+
+// readProcStat reads CPU time and RSS for pid from /proc/<pid>/stat, the
+// Linux-specific fast path.
+func readProcStat(pid int) (cpuSeconds float64, rssBytes int64, err error) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// The comm field can itself contain spaces/parens, so skip to the last
+	// ')' before splitting the fixed-position fields that follow it.
+	end := strings.LastIndexByte(string(data), ')')
+	if end < 0 || end+2 >= len(data) {
+		return 0, 0, fmt.Errorf("metrics: unexpected /proc/%d/stat format", pid)
+	}
+	fields := strings.Fields(string(data)[end+2:])
+	if len(fields) < 22 {
+		return 0, 0, fmt.Errorf("metrics: short /proc/%d/stat", pid)
+	}
+
+	utime, _ := strconv.ParseFloat(fields[11], 64)
+	stime, _ := strconv.ParseFloat(fields[12], 64)
+	rssPages, _ := strconv.ParseInt(fields[21], 10, 64)
+
+	const clockTicksPerSec = 100 // USER_HZ; sysconf(_SC_CLK_TCK) on virtually every Linux target
+	cpuSeconds = (utime + stime) / clockTicksPerSec
+	rssBytes = rssPages * int64(os.Getpagesize())
+	return cpuSeconds, rssBytes, nil
+}
+
+// readProcViaPS is the non-Linux fallback for readProcStat.
+func readProcViaPS(pid int) (cpuSeconds float64, rssBytes int64, err error) {
+	out, err := exec.Command("ps", "-o", "cputime=,rss=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	fields := strings.Fields(string(out))
+	if len(fields) != 2 {
+		return 0, 0, fmt.Errorf("metrics: unexpected ps output for pid %d: %q", pid, out)
+	}
+	cpuSeconds, err = parsePSCputime(fields[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	rssKB, err := strconv.ParseInt(fields[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	return cpuSeconds, rssKB * 1024, nil
+}
+
+// parsePSCputime parses ps's cputime format, [[DD-]HH:]MM:SS.
+func parsePSCputime(s string) (float64, error) {
+	var days int
+	if i := strings.IndexByte(s, '-'); i >= 0 {
+		d, err := strconv.Atoi(s[:i])
+		if err != nil {
+			return 0, fmt.Errorf("metrics: parsing ps cputime %q: %w", s, err)
+		}
+		days = d
+		s = s[i+1:]
+	}
+
+	parts := strings.Split(s, ":")
+	var h, m, sec int
+	var err error
+	switch len(parts) {
+	case 2:
+		m, err = strconv.Atoi(parts[0])
+		if err == nil {
+			sec, err = strconv.Atoi(parts[1])
+		}
+	case 3:
+		h, err = strconv.Atoi(parts[0])
+		if err == nil {
+			m, err = strconv.Atoi(parts[1])
+		}
+		if err == nil {
+			sec, err = strconv.Atoi(parts[2])
+		}
+	default:
+		return 0, fmt.Errorf("metrics: unexpected ps cputime format %q", s)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("metrics: parsing ps cputime %q: %w", s, err)
+	}
+	return float64(days*86400 + h*3600 + m*60 + sec), nil
+}
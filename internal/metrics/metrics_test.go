@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+)
+
+func TestParsePSCputime(t *testing.T) {
+	cases := []struct {
+		in   string
+		want float64
+	}{
+		{"01:30", 90},
+		{"01:02:03", 3723},
+		{"1-02:03:04", 93784},
+	}
+	for _, c := range cases {
+		got, err := parsePSCputime(c.in)
+		if err != nil {
+			t.Fatalf("parsePSCputime(%q) error: %v", c.in, err)
+		}
+		if got != c.want {
+			t.Errorf("parsePSCputime(%q) = %v, want %v", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParsePSCputimeInvalid(t *testing.T) {
+	if _, err := parsePSCputime("not-a-time"); err == nil {
+		t.Error("expected error for malformed cputime")
+	}
+}
+
+func TestServeReportsBindFailureOnEvents(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("net.Listen: %v", err)
+	}
+	defer l.Close()
+
+	c := New()
+	c.Serve(l.Addr().String())
+
+	select {
+	case err := <-c.Events():
+		if err == nil {
+			t.Error("expected a non-nil bind error")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected a bind-failure event, got none")
+	}
+}
@@ -0,0 +1,222 @@
+// Package progress streams a subprocess's stdout/stderr line by line,
+// parses whichever progress format that command emits, and renders the
+// result as a width-aware bar that Bubble Tea can draw under a step.
+package progress
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Update is one parsed progress sample. Total/Done are zero when the
+// underlying command doesn't report byte counts (Percent-only progress).
+type Update struct {
+	Percent float64
+	Done    int64
+	Total   int64
+	Speed   string
+	ETA     time.Duration
+	// Unit is "" for byte counts (the default, rendered via humanBytes) or
+	// "count" for plain item counters like uv's "(3/12) packages".
+	Unit string
+}
+
+// Event is one line out of a streamed command: either a parsed Update, a
+// raw log line the parser didn't recognize, or the terminal Done/Err pair
+// once the command exits.
+type Event struct {
+	Update Update
+	Line   string
+	Done   bool
+	Err    error
+}
+
+// Stream starts cmd, scans its stdout and stderr concurrently, and emits an
+// Event per line (parsed via parse, or raw if parse returns false), followed
+// by a final Event with Done set once the command exits. The returned
+// channel is closed after the final event.
+func Stream(cmd *exec.Cmd, parse func(line string) (Update, bool)) <-chan Event {
+	events := make(chan Event)
+
+	go func() {
+		defer close(events)
+
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			events <- Event{Done: true, Err: fmt.Errorf("stdout pipe: %w", err)}
+			return
+		}
+		stderr, err := cmd.StderrPipe()
+		if err != nil {
+			events <- Event{Done: true, Err: fmt.Errorf("stderr pipe: %w", err)}
+			return
+		}
+		if err := cmd.Start(); err != nil {
+			events <- Event{Done: true, Err: fmt.Errorf("start: %w", err)}
+			return
+		}
+
+		var wg sync.WaitGroup
+		scan := func(r io.Reader) {
+			defer wg.Done()
+			scanner := bufio.NewScanner(r)
+			scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+			for scanner.Scan() {
+				line := scanner.Text()
+				if upd, ok := parse(line); ok {
+					events <- Event{Update: upd, Line: line}
+				} else {
+					events <- Event{Line: line}
+				}
+			}
+		}
+
+		wg.Add(2)
+		go scan(stdout)
+		go scan(stderr)
+		wg.Wait()
+
+		events <- Event{Done: true, Err: cmd.Wait()}
+	}()
+
+	return events
+}
+
+// Bar renders an Update as a fixed-width progress bar, truncating like the
+// orchestrator's existing log lines do.
+type Bar struct {
+	Label  string
+	Width  int
+	Update Update
+}
+
+// View renders the bar, e.g. "Embedding Model [====      ]  42% 268MB/639MB 41MB/s eta 9s".
+func (b Bar) View() string {
+	width := b.Width
+	if width <= 0 {
+		width = 30
+	}
+
+	pct := b.Update.Percent
+	if pct < 0 {
+		pct = 0
+	}
+	if pct > 100 {
+		pct = 100
+	}
+	filled := int(pct / 100 * float64(width))
+	bar := "[" + strings.Repeat("=", filled) + strings.Repeat(" ", width-filled) + "]"
+
+	details := fmt.Sprintf("%3.0f%%", pct)
+	if b.Update.Total > 0 {
+		if b.Update.Unit == "count" {
+			details += fmt.Sprintf(" %d/%d", b.Update.Done, b.Update.Total)
+		} else {
+			details += fmt.Sprintf(" %s/%s", humanBytes(b.Update.Done), humanBytes(b.Update.Total))
+		}
+	}
+	if b.Update.Speed != "" {
+		details += " " + b.Update.Speed
+	}
+	if b.Update.ETA > 0 {
+		details += " eta " + b.Update.ETA.Truncate(time.Second).String()
+	}
+
+	line := bar + " " + details
+	if b.Label != "" {
+		line = b.Label + " " + line
+	}
+	return line
+}
+
+func humanBytes(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%dB", n)
+	}
+	div, exp := int64(unit), 0
+	for n/div >= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.0f%cB", float64(n)/float64(div), "KMGT"[exp])
+}
+
+var byteUnit = map[string]float64{
+	"KB": 1000,
+	"MB": 1000 * 1000,
+	"GB": 1000 * 1000 * 1000,
+}
+
+func parseBytes(amount, unit string) int64 {
+	f, err := strconv.ParseFloat(amount, 64)
+	if err != nil {
+		return 0
+	}
+	return int64(f * byteUnit[unit])
+}
+
+// ollamaPullRE matches ollama's pull progress lines, e.g.:
+//
+//	pulling 8934d96d3f08... 43% â–•â–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆ        â–– 274 MB/639 MB   98 MB/s     3s
+var ollamaPullRE = regexp.MustCompile(
+	`^pulling \S+\.\.\.\s+(\d+)%.*?(\d+(?:\.\d+)?)\s*(KB|MB|GB)/(\d+(?:\.\d+)?)\s*(KB|MB|GB)\s+(\d+(?:\.\d+)?\s*(?:KB|MB|GB))/s(?:\s+(\S+))?`,
+)
+
+// ParseOllamaPullLine parses one line of `ollama pull` output into an Update.
+// It returns false for lines that aren't a progress sample (e.g. "pulling
+// manifest", "success").
+func ParseOllamaPullLine(line string) (Update, bool) {
+	m := ollamaPullRE.FindStringSubmatch(line)
+	if m == nil {
+		return Update{}, false
+	}
+
+	percent, _ := strconv.ParseFloat(m[1], 64)
+	done := parseBytes(m[2], m[3])
+	total := parseBytes(m[4], m[5])
+	speed := strings.ReplaceAll(m[6], " ", "") + "/s"
+
+	var eta time.Duration
+	if m[7] != "" {
+		if d, err := time.ParseDuration(m[7]); err == nil {
+			eta = d
+		}
+	}
+
+	return Update{Percent: percent, Done: done, Total: total, Speed: speed, ETA: eta}, true
+}
+
+// uvProgressRE matches uv's non-interactive resolver/download counters, e.g.
+// "Downloading numpy (3/12)" or "Installing 3/12 packages". uv's default
+// terminal bar is drawn with carriage returns we don't see through a pipe,
+// so this only covers the line-oriented counters it also prints.
+var uvProgressRE = regexp.MustCompile(`\((\d+)/(\d+)\)|(\d+)/(\d+) packages`)
+
+// ParseUvSyncLine parses one line of `uv sync` output into an Update, best
+// effort. It returns false for lines with no recognizable counter.
+func ParseUvSyncLine(line string) (Update, bool) {
+	m := uvProgressRE.FindStringSubmatch(line)
+	if m == nil {
+		return Update{}, false
+	}
+
+	doneStr, totalStr := m[1], m[2]
+	if doneStr == "" {
+		doneStr, totalStr = m[3], m[4]
+	}
+	done, _ := strconv.ParseFloat(doneStr, 64)
+	total, _ := strconv.ParseFloat(totalStr, 64)
+	if total == 0 {
+		return Update{}, false
+	}
+
+	return Update{Percent: done / total * 100, Done: int64(done), Total: int64(total), Unit: "count"}, true
+}
@@ -0,0 +1,48 @@
+package progress
+
+import "testing"
+
+func TestParseOllamaPullLine(t *testing.T) {
+	line := "pulling 8934d96d3f08... 43% â–•â–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆâ–ˆ        â–– 274 MB/639 MB   98 MB/s     3s"
+
+	upd, ok := ParseOllamaPullLine(line)
+	if !ok {
+		t.Fatalf("expected line to parse, got ok=false")
+	}
+	if upd.Percent != 43 {
+		t.Errorf("Percent = %v, want 43", upd.Percent)
+	}
+	if upd.Done != 274_000_000 {
+		t.Errorf("Done = %v, want 274000000", upd.Done)
+	}
+	if upd.Total != 639_000_000 {
+		t.Errorf("Total = %v, want 639000000", upd.Total)
+	}
+	if upd.Speed != "98MB/s" {
+		t.Errorf("Speed = %q, want %q", upd.Speed, "98MB/s")
+	}
+	if upd.ETA.Seconds() != 3 {
+		t.Errorf("ETA = %v, want 3s", upd.ETA)
+	}
+}
+
+func TestParseOllamaPullLineIgnoresNonProgress(t *testing.T) {
+	for _, line := range []string{"pulling manifest", "success", ""} {
+		if _, ok := ParseOllamaPullLine(line); ok {
+			t.Errorf("ParseOllamaPullLine(%q) = ok, want not ok", line)
+		}
+	}
+}
+
+func TestParseUvSyncLine(t *testing.T) {
+	upd, ok := ParseUvSyncLine("Downloading numpy (3/12)")
+	if !ok {
+		t.Fatalf("expected line to parse, got ok=false")
+	}
+	if upd.Done != 3 || upd.Total != 12 {
+		t.Errorf("Done/Total = %d/%d, want 3/12", upd.Done, upd.Total)
+	}
+	if upd.Unit != "count" {
+		t.Errorf("Unit = %q, want %q", upd.Unit, "count")
+	}
+}
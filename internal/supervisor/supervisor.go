@@ -0,0 +1,268 @@
+// Package supervisor tracks the child processes Engram launches (ollama
+// serve, lightrag-server), restarts them with backoff if they die
+// unexpectedly, and shuts them down gracefully (SIGTERM then SIGKILL) when
+// the orchestrator exits.
+package supervisor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Status is a point-in-time snapshot of one supervised child, suitable for
+// both the TUI and `engram status` to render.
+type Status struct {
+	Name              string    `json:"name"`
+	PID               int       `json:"pid"`
+	StartTime         time.Time `json:"start_time"`
+	Restarts          int       `json:"restarts"`
+	LogPath           string    `json:"log_path"`
+	LastRestartReason string    `json:"last_restart_reason,omitempty"`
+	Exited            bool      `json:"exited"`
+}
+
+// Event is an out-of-band notice about a supervised child (restarting,
+// giving up), meant to be rendered the same way step logs are.
+type Event struct {
+	Name string
+	Msg  string
+}
+
+// child is the mutable bookkeeping behind one Status.
+type child struct {
+	status Status
+	cmd    *exec.Cmd
+	newCmd func() (*exec.Cmd, error)
+}
+
+// Supervisor owns every child process Engram starts. The zero value is not
+// usable; construct with New.
+type Supervisor struct {
+	mu          sync.Mutex
+	children    map[string]*child
+	events      chan Event
+	maxRestarts int
+	stopping    bool
+}
+
+// New returns a Supervisor that restarts a child at most maxRestarts times
+// and gives up on its next unexpected exit. The count is never reset, so
+// it covers the child's whole lifetime, not only consecutive exits.
+func New(maxRestarts int) *Supervisor {
+	return &Supervisor{
+		children:    make(map[string]*child),
+		events:      make(chan Event, 16),
+		maxRestarts: maxRestarts,
+	}
+}
+
+// Events returns the channel Restart notices and give-up notices are sent
+// on. Callers should drain it (e.g. forward into a Bubble Tea program).
+func (s *Supervisor) Events() <-chan Event {
+	return s.events
+}
+
+// Start launches a child via newCmd, records it under name, and spawns the
+// goroutine that watches for (and restarts) unexpected exits. newCmd is
+// called again on every restart so env/args/log redirection are rebuilt
+// fresh each time.
+func (s *Supervisor) Start(name string, newCmd func() (*exec.Cmd, error)) error {
+	cmd, err := newCmd()
+	if err != nil {
+		return fmt.Errorf("supervisor: building command for %s: %w", name, err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("supervisor: starting %s: %w", name, err)
+	}
+
+	s.mu.Lock()
+	s.children[name] = &child{
+		status: Status{
+			Name:      name,
+			PID:       cmd.Process.Pid,
+			StartTime: time.Now(),
+		},
+		cmd:    cmd,
+		newCmd: newCmd,
+	}
+	s.mu.Unlock()
+
+	go s.supervise(name)
+	return nil
+}
+
+func (s *Supervisor) supervise(name string) {
+	for {
+		s.mu.Lock()
+		c, ok := s.children[name]
+		var cmd *exec.Cmd
+		if ok {
+			cmd = c.cmd
+		}
+		s.mu.Unlock()
+		if !ok {
+			return
+		}
+
+		waitErr := cmd.Wait()
+
+		s.mu.Lock()
+		stopping := s.stopping
+		s.mu.Unlock()
+		if stopping {
+			return
+		}
+
+		s.mu.Lock()
+		c.status.Restarts++
+		c.status.Exited = true
+		restarts := c.status.Restarts
+		s.mu.Unlock()
+
+		if restarts > s.maxRestarts {
+			s.events <- Event{Name: name, Msg: fmt.Sprintf("gave up after %d restarts (last error: %v)", restarts-1, waitErr)}
+			return
+		}
+
+		backoff := backoffFor(restarts)
+		s.events <- Event{Name: name, Msg: fmt.Sprintf("exited unexpectedly (%v), restarting in %s (attempt %d/%d)", waitErr, backoff, restarts, s.maxRestarts)}
+		time.Sleep(backoff)
+
+		s.mu.Lock()
+		stopping = s.stopping
+		s.mu.Unlock()
+		if stopping {
+			return
+		}
+
+		newCmd, err := c.newCmd()
+		if err != nil {
+			s.events <- Event{Name: name, Msg: fmt.Sprintf("restart failed: %v", err)}
+			return
+		}
+		if err := newCmd.Start(); err != nil {
+			s.events <- Event{Name: name, Msg: fmt.Sprintf("restart failed: %v", err)}
+			return
+		}
+
+		s.mu.Lock()
+		c.cmd = newCmd
+		c.status.PID = newCmd.Process.Pid
+		c.status.StartTime = time.Now()
+		c.status.LastRestartReason = fmt.Sprintf("%v", waitErr)
+		c.status.Exited = false
+		s.mu.Unlock()
+	}
+}
+
+// backoffFor returns an exponential backoff capped at 30s.
+func backoffFor(attempt int) time.Duration {
+	d := time.Second << uint(attempt-1)
+	if d > 30*time.Second {
+		d = 30 * time.Second
+	}
+	return d
+}
+
+// Statuses returns a snapshot of every supervised child, in no particular
+// order.
+func (s *Supervisor) Statuses() []Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Status, 0, len(s.children))
+	for _, c := range s.children {
+		out = append(out, c.status)
+	}
+	return out
+}
+
+// Shutdown stops accepting restarts and terminates every child: SIGTERM
+// first, then SIGKILL for anything still alive after grace.
+func (s *Supervisor) Shutdown(grace time.Duration) {
+	s.mu.Lock()
+	s.stopping = true
+	children := make([]*child, 0, len(s.children))
+	for _, c := range s.children {
+		children = append(children, c)
+	}
+	s.mu.Unlock()
+
+	cmdOf := func(c *child) *exec.Cmd {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return c.cmd
+	}
+
+	for _, c := range children {
+		cmdOf(c).Process.Signal(syscall.SIGTERM)
+	}
+
+	deadline := time.Now().Add(grace)
+	for _, c := range children {
+		remaining := time.Until(deadline)
+		cmd := cmdOf(c)
+		if remaining <= 0 {
+			cmd.Process.Signal(syscall.SIGKILL)
+			continue
+		}
+		done := make(chan struct{})
+		go func(cmd *exec.Cmd) {
+			cmd.Process.Wait()
+			close(done)
+		}(cmd)
+		select {
+		case <-done:
+		case <-time.After(remaining):
+			cmd.Process.Signal(syscall.SIGKILL)
+		}
+	}
+}
+
+// WritePidfile serializes every child's Status to path as JSON lines, one
+// per service, for `engram status` to read back after the TUI exits.
+func WritePidfile(path string, statuses []Status) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("supervisor: writing pidfile %s: %w", path, err)
+	}
+	defer f.Close()
+
+	enc := json.NewEncoder(f)
+	for _, st := range statuses {
+		if err := enc.Encode(st); err != nil {
+			return fmt.Errorf("supervisor: encoding pidfile entry for %s: %w", st.Name, err)
+		}
+	}
+	return nil
+}
+
+// ReadPidfile parses a pidfile written by WritePidfile.
+func ReadPidfile(path string) ([]Status, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("supervisor: reading pidfile %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var statuses []Status
+	dec := json.NewDecoder(f)
+	for {
+		var st Status
+		if err := dec.Decode(&st); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return statuses, fmt.Errorf("supervisor: parsing pidfile %s: %w", path, err)
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
@@ -0,0 +1,47 @@
+package supervisor
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestRestartsOnUnexpectedExit(t *testing.T) {
+	s := New(3)
+
+	newCmd := func() (*exec.Cmd, error) {
+		return exec.Command("sh", "-c", "exit 1"), nil
+	}
+	if err := s.Start("flaky", newCmd); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	deadline := time.After(2 * time.Second)
+	for {
+		statuses := s.Statuses()
+		if len(statuses) == 1 && statuses[0].Restarts > 0 {
+			break
+		}
+		select {
+		case <-deadline:
+			t.Fatalf("child never restarted, last status: %+v", statuses)
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+}
+
+func TestShutdownDuringRestartDoesNotRace(t *testing.T) {
+	s := New(100)
+
+	newCmd := func() (*exec.Cmd, error) {
+		return exec.Command("sh", "-c", "exit 1"), nil
+	}
+	if err := s.Start("churning", newCmd); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	// Let the child exit so Shutdown lands while supervise is in its first
+	// restart backoff (1s), exercising the c.cmd read path under -race.
+	time.Sleep(20 * time.Millisecond)
+	s.Shutdown(100 * time.Millisecond)
+}